@@ -0,0 +1,98 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// App wires together the storage backend and the HTTP router. main() only
+// needs to construct one and run it behind an http.Server. The repo, users
+// and contacts repositories all share a single connection pool (repo.DB()),
+// so there is exactly one pool per process regardless of backend.
+type App struct {
+	repo     PersonRepository
+	users    UserRepository
+	contacts ContactRepository
+}
+
+// NewApp opens the configured storage backend, creates the schema, and
+// builds the router.
+func NewApp(cfg Config) (*App, error) {
+	repo, err := newPersonRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.CreatePersonsTable(); err != nil {
+		return nil, err
+	}
+
+	users, contacts, err := newAuxRepositories(cfg.DBDriver, repo.DB())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := users.CreateUsersTable(); err != nil {
+		return nil, err
+	}
+	if err := contacts.CreateContactsTable(); err != nil {
+		return nil, err
+	}
+
+	return &App{repo: repo, users: users, contacts: contacts}, nil
+}
+
+// newPersonRepository constructs the PersonRepository for the configured driver.
+func newPersonRepository(cfg Config) (PersonRepository, error) {
+	switch cfg.DBDriver {
+	case "postgres":
+		return NewPostgresPersonRepository(cfg.DBDSN)
+	case "sqlite3", "":
+		return NewSQLitePersonRepository(cfg.DBDSN)
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q", cfg.DBDriver)
+	}
+}
+
+// newAuxRepositories constructs the UserRepository and ContactRepository for
+// the configured driver, sharing db with the PersonRepository they accompany.
+func newAuxRepositories(driver string, db *sql.DB) (UserRepository, ContactRepository, error) {
+	switch driver {
+	case "postgres":
+		return NewPostgresUserRepository(db), NewPostgresContactRepository(db), nil
+	case "sqlite3", "":
+		return NewSQLiteUserRepository(db), NewSQLiteContactRepository(db), nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported DB_DRIVER %q", driver)
+	}
+}
+
+// Close releases the app's database connection pool.
+func (a *App) Close() error {
+	return a.repo.Close()
+}
+
+// Router builds the HTTP router and wires up every endpoint.
+func (a *App) Router() *mux.Router {
+	r := mux.NewRouter()
+
+	r.HandleFunc("/check", checkHandler).Methods(http.MethodGet)
+	r.HandleFunc("/register", a.registerHandler).Methods(http.MethodPost)
+	r.HandleFunc("/login", a.loginHandler).Methods(http.MethodPost)
+	r.HandleFunc("/users/{username}/role", authMiddleware(adminOnly(a.promoteUserHandler))).Methods(http.MethodPut)
+
+	r.HandleFunc("/persons", authMiddleware(a.listPersonsHandler)).Methods(http.MethodGet)
+	r.HandleFunc("/persons", authMiddleware(a.createPersonHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/persons/{id}", authMiddleware(a.getPersonHandler)).Methods(http.MethodGet)
+	r.HandleFunc("/persons/{id}", authMiddleware(adminOnly(a.updatePersonHandler))).Methods(http.MethodPut)
+	r.HandleFunc("/persons/{id}", authMiddleware(adminOnly(a.deletePersonHandler))).Methods(http.MethodDelete)
+
+	r.HandleFunc("/persons/{id}/contacts", authMiddleware(a.addContactHandler)).Methods(http.MethodPost)
+	r.HandleFunc("/persons/{id}/contacts", authMiddleware(a.listContactsHandler)).Methods(http.MethodGet)
+	r.HandleFunc("/persons/{id}/contacts/{contactID}", authMiddleware(a.removeContactHandler)).Methods(http.MethodDelete)
+
+	return r
+}
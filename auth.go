@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// contextKey avoids collisions with other packages' context keys.
+type contextKey string
+
+const claimsContextKey contextKey = "claims"
+
+// Claims are the JWT claims issued on login and validated on every protected request.
+type Claims struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// jwtSecret signs and verifies tokens. Override with the JWT_SECRET env var in production.
+var jwtSecret = []byte(envOrDefault("JWT_SECRET", "dev-secret-change-me"))
+
+// jwtExpiry controls how long issued tokens remain valid.
+var jwtExpiry = jwtExpiryFromEnv()
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func jwtExpiryFromEnv() time.Duration {
+	if v := os.Getenv("JWT_EXPIRY_MINUTES"); v != "" {
+		if minutes, err := strconv.Atoi(v); err == nil && minutes > 0 {
+			return time.Duration(minutes) * time.Minute
+		}
+	}
+	return time.Hour
+}
+
+// generateToken issues a signed HS256 JWT for the given user.
+func generateToken(user User) (string, error) {
+	claims := Claims{
+		UserID:   *user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(jwtExpiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtSecret)
+}
+
+// parseToken validates a bearer token and returns its claims.
+func parseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return jwtSecret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// authMiddleware validates the Authorization: Bearer <token> header and injects the
+// parsed claims into the request context. Unauthenticated requests get a 401.
+func authMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			respondWithError(w, http.StatusUnauthorized, "missing authorization header")
+			return
+		}
+
+		parts := strings.SplitN(header, " ", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+			respondWithError(w, http.StatusUnauthorized, "invalid authorization header")
+			return
+		}
+
+		claims, err := parseToken(parts[1])
+		if err != nil {
+			log.Printf("Error validating token: %v", err)
+			respondWithError(w, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), claimsContextKey, claims)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// adminOnly wraps a handler so it only runs for requests bearing the admin role.
+// It must be applied inside authMiddleware so claims are already on the context.
+func adminOnly(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value(claimsContextKey).(*Claims)
+		if !ok || claims.Role != "admin" {
+			respondWithError(w, http.StatusForbidden, "admin role required")
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config selects and configures the storage backend.
+type Config struct {
+	DBDriver string `yaml:"db_driver"`
+	DBDSN    string `yaml:"db_dsn"`
+}
+
+const configFileEnv = "CONFIG_FILE"
+const defaultConfigFile = "config.yaml"
+
+// LoadConfig resolves the storage backend configuration. DB_DRIVER and DB_DSN
+// env vars take precedence; otherwise a YAML config file (CONFIG_FILE, default
+// "config.yaml") is read if present; otherwise it falls back to a local SQLite
+// database file.
+func LoadConfig() (Config, error) {
+	cfg := Config{
+		DBDriver: "sqlite3",
+		DBDSN:    "myDataBase.db",
+	}
+
+	configFile := envOrDefault(configFileEnv, defaultConfigFile)
+	if data, err := os.ReadFile(configFile); err == nil {
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if v := os.Getenv("DB_DRIVER"); v != "" {
+		cfg.DBDriver = v
+	}
+	if v := os.Getenv("DB_DSN"); v != "" {
+		cfg.DBDSN = v
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// addContactRequest identifies the contact to add, either by existing person id
+// or by mobile number (which creates a stub person if none matches yet).
+type addContactRequest struct {
+	ContactID *int   `json:"contact_id"`
+	Mobile    string `json:"mobile"`
+}
+
+var (
+	errContactIdentifierRequired = errors.New("contact_id or mobile is required")
+	errInvalidContactMobile      = errors.New("mobile must be a valid phone number")
+)
+
+// ContactRepository is the storage interface for contacts, implemented per
+// backend (mirrors PersonRepository) so its queries use the right
+// placeholder syntax.
+type ContactRepository interface {
+	CreateContactsTable() error
+	// AddContact links ownerID to a contact person, resolving or creating
+	// that person from req, and returns the linked Person.
+	AddContact(ownerID int, req addContactRequest) (Person, error)
+	ListContacts(ownerID int) ([]Person, error)
+	RemoveContact(ownerID, contactID int) error
+}
+
+// addContactHandler handles POST /persons/{id}/contacts.
+func (a *App) addContactHandler(w http.ResponseWriter, r *http.Request) {
+	ownerID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	if _, err := a.repo.GetPersonByID(ownerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "failed to get person")
+		return
+	}
+
+	var req addContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid JSON data")
+		return
+	}
+
+	contact, err := a.contacts.AddContact(ownerID, req)
+	if err != nil {
+		if errors.Is(err, errContactIdentifierRequired) || errors.Is(err, errInvalidContactMobile) {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "failed to add contact")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, contact)
+}
+
+// listContactsHandler handles GET /persons/{id}/contacts.
+func (a *App) listContactsHandler(w http.ResponseWriter, r *http.Request) {
+	ownerID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	contacts, err := a.contacts.ListContacts(ownerID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "failed to list contacts")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, contacts)
+}
+
+// removeContactHandler handles DELETE /persons/{id}/contacts/{contactID}.
+func (a *App) removeContactHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ownerID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	contactID, err := strconv.Atoi(vars["contactID"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid contact ID")
+		return
+	}
+
+	if err := a.contacts.RemoveContact(ownerID, contactID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "failed to remove contact")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
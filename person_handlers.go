@@ -0,0 +1,223 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// personListEnvelope is the JSON body returned by listPersonsHandler.
+type personListEnvelope struct {
+	Data   []Person `json:"data"`
+	Total  int      `json:"total"`
+	Limit  int      `json:"limit"`
+	Offset int      `json:"offset"`
+}
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// getPersonHandler handles GET /persons/{id}.
+func (a *App) getPersonHandler(w http.ResponseWriter, r *http.Request) {
+	personID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	person, err := a.repo.GetPersonByID(personID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "failed to get person")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, person)
+}
+
+// listPersonsHandler handles GET /persons.
+func (a *App) listPersonsHandler(w http.ResponseWriter, r *http.Request) {
+	params, err := parsePersonListParams(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	persons, err := a.repo.ListPersons(params)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "failed to list persons")
+		return
+	}
+
+	total, err := a.repo.CountPersons(params)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "failed to list persons")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, personListEnvelope{
+		Data:   persons,
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+}
+
+// parsePersonListParams validates and defaults the ?limit=&offset=&sort=&order=&q=
+// query parameters accepted by listPersonsHandler.
+func parsePersonListParams(r *http.Request) (PersonListParams, error) {
+	query := r.URL.Query()
+	params := PersonListParams{
+		Limit:  defaultListLimit,
+		Offset: 0,
+		Sort:   "id",
+		Order:  "asc",
+		Query:  query.Get("q"),
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxListLimit {
+			return params, fmt.Errorf("limit must be an integer between 1 and %d", maxListLimit)
+		}
+		params.Limit = limit
+	}
+
+	if v := query.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return params, errors.New("offset must be a non-negative integer")
+		}
+		params.Offset = offset
+	}
+
+	if v := query.Get("sort"); v != "" {
+		if _, ok := sortableColumns[v]; !ok {
+			return params, fmt.Errorf("sort must be one of name, email, id")
+		}
+		params.Sort = v
+	}
+
+	if v := query.Get("order"); v != "" {
+		if !strings.EqualFold(v, "asc") && !strings.EqualFold(v, "desc") {
+			return params, errors.New("order must be asc or desc")
+		}
+		params.Order = v
+	}
+
+	return params, nil
+}
+
+// createPersonHandler handles POST /persons.
+func (a *App) createPersonHandler(w http.ResponseWriter, r *http.Request) {
+	var newPerson Person
+	if err := json.NewDecoder(r.Body).Decode(&newPerson); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid JSON data")
+		return
+	}
+
+	if err := newPerson.Validate(); err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			respondWithValidationError(w, validationErr)
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	created, err := a.repo.InsertPerson(newPerson)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateEmail) {
+			respondWithError(w, http.StatusConflict, "a person with this email already exists")
+			return
+		}
+		if errors.Is(err, ErrDuplicateMobile) {
+			respondWithError(w, http.StatusConflict, "a person with this mobile already exists")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "failed to create person")
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/persons/%d", *created.ID))
+	respondWithJSON(w, http.StatusCreated, created)
+}
+
+// updatePersonHandler handles PUT /persons/{id}.
+func (a *App) updatePersonHandler(w http.ResponseWriter, r *http.Request) {
+	personID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	if _, err := a.repo.GetPersonByID(personID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, http.StatusNotFound, "person not found")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "failed to get person")
+		return
+	}
+
+	var updatedPerson Person
+	if err := json.NewDecoder(r.Body).Decode(&updatedPerson); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid JSON data")
+		return
+	}
+	updatedPerson.ID = &personID
+
+	if err := updatedPerson.Validate(); err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			respondWithValidationError(w, validationErr)
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := a.repo.UpdatePerson(updatedPerson)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateEmail) {
+			respondWithError(w, http.StatusConflict, "a person with this email already exists")
+			return
+		}
+		if errors.Is(err, ErrDuplicateMobile) {
+			respondWithError(w, http.StatusConflict, "a person with this mobile already exists")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "failed to update person")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
+// deletePersonHandler handles DELETE /persons/{id}.
+func (a *App) deletePersonHandler(w http.ResponseWriter, r *http.Request) {
+	personID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	if err := a.repo.DeletePerson(personID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "failed to delete person")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
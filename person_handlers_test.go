@@ -0,0 +1,82 @@
+package main
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParsePersonListParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    PersonListParams
+		wantErr bool
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want:  PersonListParams{Limit: defaultListLimit, Offset: 0, Sort: "id", Order: "asc", Query: ""},
+		},
+		{
+			name:  "all params set",
+			query: "limit=10&offset=5&sort=name&order=desc&q=ray",
+			want:  PersonListParams{Limit: 10, Offset: 5, Sort: "name", Order: "desc", Query: "ray"},
+		},
+		{
+			name:    "limit too low",
+			query:   "limit=0",
+			wantErr: true,
+		},
+		{
+			name:    "limit too high",
+			query:   "limit=101",
+			wantErr: true,
+		},
+		{
+			name:    "limit not an integer",
+			query:   "limit=abc",
+			wantErr: true,
+		},
+		{
+			name:    "negative offset",
+			query:   "offset=-1",
+			wantErr: true,
+		},
+		{
+			name:    "unsortable column",
+			query:   "sort=password_hash",
+			wantErr: true,
+		},
+		{
+			name:    "invalid order",
+			query:   "order=sideways",
+			wantErr: true,
+		},
+		{
+			name:  "order is case-insensitive",
+			query: "order=DESC",
+			want:  PersonListParams{Limit: defaultListLimit, Offset: 0, Sort: "id", Order: "DESC", Query: ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/persons?"+tt.query, nil)
+			got, err := parsePersonListParams(r)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsePersonListParams(%q) error = nil, want error", tt.query)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parsePersonListParams(%q) error = %v, want nil", tt.query, err)
+			}
+			if got != tt.want {
+				t.Errorf("parsePersonListParams(%q) = %+v, want %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
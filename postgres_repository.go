@@ -0,0 +1,415 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// PostgresPersonRepository is the PersonRepository backend used when
+// DB_DRIVER is "postgres".
+type PostgresPersonRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPersonRepository opens a Postgres database at dsn.
+func NewPostgresPersonRepository(dsn string) (*PostgresPersonRepository, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &PostgresPersonRepository{db: db}, nil
+}
+
+func (r *PostgresPersonRepository) Close() error {
+	return r.db.Close()
+}
+
+// DB returns the repository's underlying connection pool.
+func (r *PostgresPersonRepository) DB() *sql.DB {
+	return r.db
+}
+
+func (r *PostgresPersonRepository) CreatePersonsTable() error {
+	const query = `
+	CREATE TABLE IF NOT EXISTS persons (
+		id SERIAL PRIMARY KEY,
+		name VARCHAR(40) NOT NULL,
+		email VARCHAR(50) UNIQUE,
+		mobile VARCHAR(25) NOT NULL UNIQUE
+	);`
+
+	_, err := r.db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating 'persons' table: %v", err)
+	}
+	return err
+}
+
+func (r *PostgresPersonRepository) InsertPerson(person Person) (Person, error) {
+	const query = `
+	INSERT INTO persons (name, email, mobile)
+	VALUES ($1, $2, $3)
+	RETURNING id, name, COALESCE(email, ''), mobile;`
+
+	var p Person
+	err := r.db.QueryRow(query, person.Name, person.Email, person.Mobile).
+		Scan(&p.ID, &p.Name, &p.Email, &p.Mobile)
+	if err != nil {
+		if dupErr := uniquePostgresConstraintError(err); dupErr != nil {
+			return Person{}, dupErr
+		}
+		log.Printf("Error inserting person: %v", err)
+		return Person{}, err
+	}
+
+	return p, nil
+}
+
+func (r *PostgresPersonRepository) ListPersons(params PersonListParams) ([]Person, error) {
+	column, ok := sortableColumns[params.Sort]
+	if !ok {
+		column = "id"
+	}
+	order := "ASC"
+	if strings.EqualFold(params.Order, "desc") {
+		order = "DESC"
+	}
+
+	query := fmt.Sprintf(`
+	SELECT id, name, COALESCE(email, ''), mobile FROM persons
+	WHERE name ILIKE $1 OR email ILIKE $1 OR mobile ILIKE $1
+	ORDER BY %s %s
+	LIMIT $2 OFFSET $3;`, column, order)
+
+	like := "%" + params.Query + "%"
+	rows, err := r.db.Query(query, like, params.Limit, params.Offset)
+	if err != nil {
+		log.Printf("Error listing persons: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var persons []Person
+	for rows.Next() {
+		var p Person
+		if err := rows.Scan(&p.ID, &p.Name, &p.Email, &p.Mobile); err != nil {
+			log.Printf("Error scanning person: %v", err)
+			return nil, err
+		}
+		persons = append(persons, p)
+	}
+
+	return persons, nil
+}
+
+func (r *PostgresPersonRepository) CountPersons(params PersonListParams) (int, error) {
+	const query = `
+	SELECT COUNT(*) FROM persons
+	WHERE name ILIKE $1 OR email ILIKE $1 OR mobile ILIKE $1;`
+
+	like := "%" + params.Query + "%"
+	var total int
+	if err := r.db.QueryRow(query, like).Scan(&total); err != nil {
+		log.Printf("Error counting persons: %v", err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *PostgresPersonRepository) GetPersonByID(id int) (Person, error) {
+	const query = `SELECT id, name, COALESCE(email, ''), mobile FROM persons WHERE id = $1;`
+
+	row := r.db.QueryRow(query, id)
+	var p Person
+	if err := row.Scan(&p.ID, &p.Name, &p.Email, &p.Mobile); err != nil {
+		return Person{}, err
+	}
+
+	return p, nil
+}
+
+func (r *PostgresPersonRepository) UpdatePerson(person Person) (Person, error) {
+	const query = `
+	UPDATE persons
+	SET name=$1, email=$2, mobile=$3
+	WHERE id=$4
+	RETURNING id, name, COALESCE(email, ''), mobile;`
+
+	if person.ID == nil {
+		return Person{}, fmt.Errorf("person ID is nil, cannot update")
+	}
+
+	var p Person
+	err := r.db.QueryRow(query, person.Name, person.Email, person.Mobile, *person.ID).
+		Scan(&p.ID, &p.Name, &p.Email, &p.Mobile)
+	if err != nil {
+		if dupErr := uniquePostgresConstraintError(err); dupErr != nil {
+			return Person{}, dupErr
+		}
+		log.Printf("Error updating person: %v", err)
+		return Person{}, fmt.Errorf("failed to update person with ID %d: %v", *person.ID, err)
+	}
+
+	return p, nil
+}
+
+func (r *PostgresPersonRepository) DeletePerson(id int) error {
+	const query = `DELETE FROM persons WHERE id=$1;`
+
+	if _, err := r.db.Exec(query, id); err != nil {
+		log.Printf("Error deleting person: %v", err)
+		return err
+	}
+	return nil
+}
+
+// PostgresContactRepository is the ContactRepository backend used alongside
+// PostgresPersonRepository. It shares that repository's connection pool.
+type PostgresContactRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresContactRepository wraps an already-open Postgres connection pool.
+func NewPostgresContactRepository(db *sql.DB) *PostgresContactRepository {
+	return &PostgresContactRepository{db: db}
+}
+
+func (r *PostgresContactRepository) CreateContactsTable() error {
+	const query = `
+	CREATE TABLE IF NOT EXISTS contacts (
+		owner_id INTEGER NOT NULL,
+		contact_id INTEGER NOT NULL,
+		PRIMARY KEY (owner_id, contact_id),
+		FOREIGN KEY (owner_id) REFERENCES persons(id) ON DELETE CASCADE,
+		FOREIGN KEY (contact_id) REFERENCES persons(id) ON DELETE CASCADE
+	);`
+
+	_, err := r.db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating 'contacts' table: %v", err)
+	}
+	return err
+}
+
+func (r *PostgresContactRepository) AddContact(ownerID int, req addContactRequest) (Person, error) {
+	var contactID int
+
+	switch {
+	case req.ContactID != nil:
+		contactID = *req.ContactID
+	case req.Mobile != "":
+		mobile, ok := ParsePhone(req.Mobile)
+		if !ok {
+			return Person{}, errInvalidContactMobile
+		}
+
+		const upsertQuery = `
+		INSERT INTO persons (name, mobile)
+		VALUES ('', $1)
+		ON CONFLICT(mobile) DO UPDATE SET mobile = excluded.mobile
+		RETURNING id;`
+
+		if err := r.db.QueryRow(upsertQuery, mobile).Scan(&contactID); err != nil {
+			log.Printf("Error upserting contact by mobile: %v", err)
+			return Person{}, err
+		}
+	default:
+		return Person{}, errContactIdentifierRequired
+	}
+
+	const linkQuery = `
+	INSERT INTO contacts (owner_id, contact_id)
+	VALUES ($1, $2)
+	ON CONFLICT(owner_id, contact_id) DO NOTHING;`
+
+	if _, err := r.db.Exec(linkQuery, ownerID, contactID); err != nil {
+		log.Printf("Error linking contact: %v", err)
+		return Person{}, err
+	}
+
+	return r.getPersonByID(contactID)
+}
+
+// getPersonByID looks up a single person directly over r.db, so AddContact
+// can fetch the linked person without depending on the App's PersonRepository.
+func (r *PostgresContactRepository) getPersonByID(id int) (Person, error) {
+	const query = `SELECT id, name, COALESCE(email, ''), mobile FROM persons WHERE id = $1;`
+
+	row := r.db.QueryRow(query, id)
+	var p Person
+	if err := row.Scan(&p.ID, &p.Name, &p.Email, &p.Mobile); err != nil {
+		return Person{}, err
+	}
+
+	return p, nil
+}
+
+func (r *PostgresContactRepository) ListContacts(ownerID int) ([]Person, error) {
+	const query = `
+	SELECT persons.id, persons.name, COALESCE(persons.email, ''), persons.mobile
+	FROM contacts
+	JOIN persons ON persons.id = contacts.contact_id
+	WHERE contacts.owner_id = $1
+	ORDER BY persons.id;`
+
+	rows, err := r.db.Query(query, ownerID)
+	if err != nil {
+		log.Printf("Error listing contacts: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contacts []Person
+	for rows.Next() {
+		var p Person
+		if err := rows.Scan(&p.ID, &p.Name, &p.Email, &p.Mobile); err != nil {
+			log.Printf("Error scanning contact: %v", err)
+			return nil, err
+		}
+		contacts = append(contacts, p)
+	}
+
+	return contacts, nil
+}
+
+func (r *PostgresContactRepository) RemoveContact(ownerID, contactID int) error {
+	const query = `DELETE FROM contacts WHERE owner_id = $1 AND contact_id = $2;`
+
+	if _, err := r.db.Exec(query, ownerID, contactID); err != nil {
+		log.Printf("Error removing contact: %v", err)
+		return err
+	}
+	return nil
+}
+
+// PostgresUserRepository is the UserRepository backend used alongside
+// PostgresPersonRepository. It shares that repository's connection pool.
+type PostgresUserRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUserRepository wraps an already-open Postgres connection pool.
+func NewPostgresUserRepository(db *sql.DB) *PostgresUserRepository {
+	return &PostgresUserRepository{db: db}
+}
+
+func (r *PostgresUserRepository) CreateUsersTable() error {
+	const query = `
+	CREATE TABLE IF NOT EXISTS users (
+		id SERIAL PRIMARY KEY,
+		username VARCHAR(40) NOT NULL UNIQUE,
+		password_hash VARCHAR(60) NOT NULL,
+		role VARCHAR(20) NOT NULL DEFAULT 'user'
+	);`
+
+	_, err := r.db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating 'users' table: %v", err)
+	}
+	return err
+}
+
+// registerLockKey is an arbitrary, fixed key for pg_advisory_xact_lock, used
+// by Register to serialize the "first user becomes admin" bootstrap check.
+const registerLockKey = 727366452
+
+// Register inserts user inside a transaction that also decides its role, so
+// the "first user becomes admin" bootstrap check can't race. A session-level
+// advisory lock (released automatically at commit/rollback) serializes it
+// against any other in-flight Register call, since Postgres's default READ
+// COMMITTED isolation wouldn't otherwise stop two transactions from both
+// reading count=0.
+func (r *PostgresUserRepository) Register(user User) (User, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return User{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1);`, registerLockKey); err != nil {
+		return User{}, err
+	}
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM users;`).Scan(&count); err != nil {
+		return User{}, err
+	}
+	user.Role = "user"
+	if count == 0 {
+		user.Role = "admin"
+	}
+
+	const query = `
+	INSERT INTO users (username, password_hash, role)
+	VALUES ($1, $2, $3)
+	RETURNING id;`
+
+	var id int
+	if err := tx.QueryRow(query, user.Username, user.PasswordHash, user.Role).Scan(&id); err != nil {
+		log.Printf("Error inserting user: %v", err)
+		return User{}, err
+	}
+	user.ID = &id
+
+	if err := tx.Commit(); err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+func (r *PostgresUserRepository) GetUserByUsername(username string) (User, error) {
+	const query = `SELECT id, username, password_hash, role FROM users WHERE username = $1;`
+
+	row := r.db.QueryRow(query, username)
+	var u User
+	if err := row.Scan(&u.ID, &u.Username, &u.PasswordHash, &u.Role); err != nil {
+		return User{}, err
+	}
+
+	return u, nil
+}
+
+func (r *PostgresUserRepository) UpdateUserRole(username, role string) error {
+	const query = `UPDATE users SET role = $1 WHERE username = $2;`
+
+	result, err := r.db.Exec(query, role, username)
+	if err != nil {
+		log.Printf("Error updating user role: %v", err)
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// uniquePostgresConstraintError reports which person field a Postgres UNIQUE
+// constraint violation (SQLSTATE 23505) was against, returning nil if err
+// isn't such a violation. The constraint name (e.g. "persons_email_key") is
+// the only way to tell a duplicate email apart from a duplicate mobile number.
+func uniquePostgresConstraintError(err error) error {
+	pqErr, ok := err.(*pq.Error)
+	if !ok || pqErr.Code != "23505" {
+		return nil
+	}
+
+	switch {
+	case strings.Contains(pqErr.Constraint, "mobile"):
+		return ErrDuplicateMobile
+	case strings.Contains(pqErr.Constraint, "email"):
+		return ErrDuplicateEmail
+	default:
+		return ErrDuplicateEmail
+	}
+}
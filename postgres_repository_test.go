@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestUniquePostgresConstraintError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "email constraint",
+			err:  &pq.Error{Code: "23505", Constraint: "persons_email_key"},
+			want: ErrDuplicateEmail,
+		},
+		{
+			name: "mobile constraint",
+			err:  &pq.Error{Code: "23505", Constraint: "persons_mobile_key"},
+			want: ErrDuplicateMobile,
+		},
+		{
+			name: "unrecognized constraint defaults to email",
+			err:  &pq.Error{Code: "23505", Constraint: "persons_some_other_key"},
+			want: ErrDuplicateEmail,
+		},
+		{
+			name: "not a unique violation",
+			err:  &pq.Error{Code: "23503", Constraint: "persons_mobile_key"},
+			want: nil,
+		},
+		{
+			name: "not a pq error at all",
+			err:  errors.New("boom"),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := uniquePostgresConstraintError(tt.err)
+			if !errors.Is(got, tt.want) {
+				t.Errorf("uniquePostgresConstraintError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
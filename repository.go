@@ -0,0 +1,48 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// ErrDuplicateEmail is returned by PersonRepository.InsertPerson and UpdatePerson
+// when the given email is already used by another person, regardless of backend.
+var ErrDuplicateEmail = errors.New("person with this email already exists")
+
+// ErrDuplicateMobile is returned by PersonRepository.InsertPerson and UpdatePerson
+// when the given mobile number is already used by another person.
+var ErrDuplicateMobile = errors.New("person with this mobile already exists")
+
+// sortableColumns safe-lists the columns ListPersons may sort by, so user input
+// can never be interpolated directly into the ORDER BY clause.
+var sortableColumns = map[string]string{
+	"id":    "id",
+	"name":  "name",
+	"email": "email",
+}
+
+// PersonListParams controls pagination, filtering and sorting for ListPersons.
+type PersonListParams struct {
+	Limit  int
+	Offset int
+	Sort   string // one of sortableColumns' keys
+	Order  string // "asc" or "desc"
+	Query  string // substring match against name, email and mobile
+}
+
+// PersonRepository is the storage interface for persons, implemented by a
+// backend-specific type (SQLite, Postgres, ...) so the rest of the app never
+// depends on a particular database driver.
+type PersonRepository interface {
+	CreatePersonsTable() error
+	InsertPerson(person Person) (Person, error)
+	ListPersons(params PersonListParams) ([]Person, error)
+	CountPersons(params PersonListParams) (int, error)
+	GetPersonByID(id int) (Person, error)
+	UpdatePerson(person Person) (Person, error)
+	DeletePerson(id int) error
+	// DB returns the backend's underlying connection pool, so the rest of the
+	// app (users, contacts) can share it instead of opening a second one.
+	DB() *sql.DB
+	Close() error
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// SQLitePersonRepository is the default PersonRepository backend, used when
+// DB_DRIVER is unset or "sqlite3".
+type SQLitePersonRepository struct {
+	db *sql.DB
+}
+
+// NewSQLitePersonRepository opens a SQLite database at dsn with foreign key
+// enforcement enabled so related rows (e.g. contacts) cascade-delete
+// correctly, and transactions taking a write lock up front so they serialize
+// cleanly (see SQLiteUserRepository.Register). Both are requested via DSN
+// parameters rather than one-off Exec'd PRAGMAs, because database/sql pools
+// multiple physical connections and mattn/go-sqlite3 only applies a bare
+// Exec'd PRAGMA to the single connection that ran it; every other connection
+// in the pool would silently fall back to the defaults (foreign keys off,
+// deferred transactions).
+func NewSQLitePersonRepository(dsn string) (*SQLitePersonRepository, error) {
+	db, err := sql.Open("sqlite3", withSQLiteDSNParams(dsn))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLitePersonRepository{db: db}, nil
+}
+
+// withSQLiteDSNParams appends the DSN parameters mattn/go-sqlite3 applies to
+// every connection it opens (_foreign_keys=on, _txlock=immediate), regardless
+// of whether dsn already has other query parameters.
+func withSQLiteDSNParams(dsn string) string {
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	return dsn + sep + "_foreign_keys=on&_txlock=immediate"
+}
+
+func (r *SQLitePersonRepository) Close() error {
+	return r.db.Close()
+}
+
+// DB returns the repository's underlying connection pool.
+func (r *SQLitePersonRepository) DB() *sql.DB {
+	return r.db
+}
+
+func (r *SQLitePersonRepository) CreatePersonsTable() error {
+	const query = `
+	CREATE TABLE IF NOT EXISTS persons (
+		id INTEGER NOT NULL PRIMARY KEY,
+		name CHAR(40) NOT NULL,
+		email CHAR(50) UNIQUE,
+		mobile CHAR(25) NOT NULL UNIQUE
+	);`
+
+	_, err := r.db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating 'persons' table: %v", err)
+	}
+	return err
+}
+
+func (r *SQLitePersonRepository) InsertPerson(person Person) (Person, error) {
+	const query = `
+	INSERT INTO persons (name, email, mobile)
+	VALUES (?, ?, ?);`
+
+	result, err := r.db.Exec(query, person.Name, person.Email, person.Mobile)
+	if err != nil {
+		if dupErr := uniqueConstraintError(err); dupErr != nil {
+			return Person{}, dupErr
+		}
+		log.Printf("Error inserting person: %v", err)
+		return Person{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return Person{}, err
+	}
+
+	return r.GetPersonByID(int(id))
+}
+
+func (r *SQLitePersonRepository) ListPersons(params PersonListParams) ([]Person, error) {
+	column, ok := sortableColumns[params.Sort]
+	if !ok {
+		column = "id"
+	}
+	order := "ASC"
+	if strings.EqualFold(params.Order, "desc") {
+		order = "DESC"
+	}
+
+	query := fmt.Sprintf(`
+	SELECT id, name, COALESCE(email, ''), mobile FROM persons
+	WHERE name LIKE ? OR email LIKE ? OR mobile LIKE ?
+	ORDER BY %s %s
+	LIMIT ? OFFSET ?;`, column, order)
+
+	like := "%" + params.Query + "%"
+	rows, err := r.db.Query(query, like, like, like, params.Limit, params.Offset)
+	if err != nil {
+		log.Printf("Error listing persons: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var persons []Person
+	for rows.Next() {
+		var p Person
+		if err := rows.Scan(&p.ID, &p.Name, &p.Email, &p.Mobile); err != nil {
+			log.Printf("Error scanning person: %v", err)
+			return nil, err
+		}
+		persons = append(persons, p)
+	}
+
+	return persons, nil
+}
+
+func (r *SQLitePersonRepository) CountPersons(params PersonListParams) (int, error) {
+	const query = `
+	SELECT COUNT(*) FROM persons
+	WHERE name LIKE ? OR email LIKE ? OR mobile LIKE ?;`
+
+	like := "%" + params.Query + "%"
+	var total int
+	if err := r.db.QueryRow(query, like, like, like).Scan(&total); err != nil {
+		log.Printf("Error counting persons: %v", err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *SQLitePersonRepository) GetPersonByID(id int) (Person, error) {
+	const query = `SELECT id, name, COALESCE(email, ''), mobile FROM persons WHERE id = ?;`
+
+	row := r.db.QueryRow(query, id)
+	var p Person
+	if err := row.Scan(&p.ID, &p.Name, &p.Email, &p.Mobile); err != nil {
+		return Person{}, err
+	}
+
+	return p, nil
+}
+
+func (r *SQLitePersonRepository) UpdatePerson(person Person) (Person, error) {
+	const query = `
+	UPDATE persons
+	SET name=?, email=?, mobile=?
+	WHERE id=?;`
+
+	if person.ID == nil {
+		return Person{}, fmt.Errorf("person ID is nil, cannot update")
+	}
+
+	if _, err := r.db.Exec(query, person.Name, person.Email, person.Mobile, *person.ID); err != nil {
+		if dupErr := uniqueConstraintError(err); dupErr != nil {
+			return Person{}, dupErr
+		}
+		log.Printf("Error updating person: %v", err)
+		return Person{}, fmt.Errorf("failed to update person with ID %d: %v", *person.ID, err)
+	}
+
+	return r.GetPersonByID(*person.ID)
+}
+
+func (r *SQLitePersonRepository) DeletePerson(id int) error {
+	const query = `DELETE FROM persons WHERE id=?;`
+
+	if _, err := r.db.Exec(query, id); err != nil {
+		log.Printf("Error deleting person: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SQLiteContactRepository is the ContactRepository backend used alongside
+// SQLitePersonRepository. It shares that repository's connection pool.
+type SQLiteContactRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteContactRepository wraps an already-open SQLite connection pool.
+func NewSQLiteContactRepository(db *sql.DB) *SQLiteContactRepository {
+	return &SQLiteContactRepository{db: db}
+}
+
+func (r *SQLiteContactRepository) CreateContactsTable() error {
+	const query = `
+	CREATE TABLE IF NOT EXISTS contacts (
+		owner_id INTEGER NOT NULL,
+		contact_id INTEGER NOT NULL,
+		PRIMARY KEY (owner_id, contact_id),
+		FOREIGN KEY (owner_id) REFERENCES persons(id) ON DELETE CASCADE,
+		FOREIGN KEY (contact_id) REFERENCES persons(id) ON DELETE CASCADE
+	);`
+
+	_, err := r.db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating 'contacts' table: %v", err)
+	}
+	return err
+}
+
+func (r *SQLiteContactRepository) AddContact(ownerID int, req addContactRequest) (Person, error) {
+	var contactID int
+
+	switch {
+	case req.ContactID != nil:
+		contactID = *req.ContactID
+	case req.Mobile != "":
+		mobile, ok := ParsePhone(req.Mobile)
+		if !ok {
+			return Person{}, errInvalidContactMobile
+		}
+
+		const upsertQuery = `
+		INSERT INTO persons (name, mobile)
+		VALUES ('', ?)
+		ON CONFLICT(mobile) DO UPDATE SET mobile = excluded.mobile
+		RETURNING id;`
+
+		if err := r.db.QueryRow(upsertQuery, mobile).Scan(&contactID); err != nil {
+			log.Printf("Error upserting contact by mobile: %v", err)
+			return Person{}, err
+		}
+	default:
+		return Person{}, errContactIdentifierRequired
+	}
+
+	const linkQuery = `
+	INSERT INTO contacts (owner_id, contact_id)
+	VALUES (?, ?)
+	ON CONFLICT(owner_id, contact_id) DO NOTHING;`
+
+	if _, err := r.db.Exec(linkQuery, ownerID, contactID); err != nil {
+		log.Printf("Error linking contact: %v", err)
+		return Person{}, err
+	}
+
+	return r.getPersonByID(contactID)
+}
+
+// getPersonByID looks up a single person directly over r.db, so AddContact
+// can fetch the linked person without depending on the App's PersonRepository.
+func (r *SQLiteContactRepository) getPersonByID(id int) (Person, error) {
+	const query = `SELECT id, name, COALESCE(email, ''), mobile FROM persons WHERE id = ?;`
+
+	row := r.db.QueryRow(query, id)
+	var p Person
+	if err := row.Scan(&p.ID, &p.Name, &p.Email, &p.Mobile); err != nil {
+		return Person{}, err
+	}
+
+	return p, nil
+}
+
+func (r *SQLiteContactRepository) ListContacts(ownerID int) ([]Person, error) {
+	const query = `
+	SELECT persons.id, persons.name, COALESCE(persons.email, ''), persons.mobile
+	FROM contacts
+	JOIN persons ON persons.id = contacts.contact_id
+	WHERE contacts.owner_id = ?
+	ORDER BY persons.id;`
+
+	rows, err := r.db.Query(query, ownerID)
+	if err != nil {
+		log.Printf("Error listing contacts: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contacts []Person
+	for rows.Next() {
+		var p Person
+		if err := rows.Scan(&p.ID, &p.Name, &p.Email, &p.Mobile); err != nil {
+			log.Printf("Error scanning contact: %v", err)
+			return nil, err
+		}
+		contacts = append(contacts, p)
+	}
+
+	return contacts, nil
+}
+
+func (r *SQLiteContactRepository) RemoveContact(ownerID, contactID int) error {
+	const query = `DELETE FROM contacts WHERE owner_id = ? AND contact_id = ?;`
+
+	if _, err := r.db.Exec(query, ownerID, contactID); err != nil {
+		log.Printf("Error removing contact: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SQLiteUserRepository is the UserRepository backend used alongside
+// SQLitePersonRepository. It shares that repository's connection pool.
+type SQLiteUserRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteUserRepository wraps an already-open SQLite connection pool.
+func NewSQLiteUserRepository(db *sql.DB) *SQLiteUserRepository {
+	return &SQLiteUserRepository{db: db}
+}
+
+func (r *SQLiteUserRepository) CreateUsersTable() error {
+	const query = `
+	CREATE TABLE IF NOT EXISTS users (
+		id INTEGER NOT NULL PRIMARY KEY,
+		username CHAR(40) NOT NULL UNIQUE,
+		password_hash CHAR(60) NOT NULL,
+		role CHAR(20) NOT NULL DEFAULT 'user'
+	);`
+
+	_, err := r.db.Exec(query)
+	if err != nil {
+		log.Printf("Error creating 'users' table: %v", err)
+	}
+	return err
+}
+
+// Register inserts user inside a transaction that also decides its role, so
+// the "first user becomes admin" bootstrap check can't race: the connection's
+// _txlock=immediate DSN parameter (see withImmediateTxLock) makes this Begin
+// take SQLite's write lock up front, serializing it against any other
+// in-flight Register call.
+func (r *SQLiteUserRepository) Register(user User) (User, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return User{}, err
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM users;`).Scan(&count); err != nil {
+		return User{}, err
+	}
+	user.Role = "user"
+	if count == 0 {
+		user.Role = "admin"
+	}
+
+	const query = `
+	INSERT INTO users (username, password_hash, role)
+	VALUES (?, ?, ?);`
+
+	result, err := tx.Exec(query, user.Username, user.PasswordHash, user.Role)
+	if err != nil {
+		log.Printf("Error inserting user: %v", err)
+		return User{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return User{}, err
+	}
+	idInt := int(id)
+	user.ID = &idInt
+
+	if err := tx.Commit(); err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+func (r *SQLiteUserRepository) GetUserByUsername(username string) (User, error) {
+	const query = `SELECT id, username, password_hash, role FROM users WHERE username = ?;`
+
+	row := r.db.QueryRow(query, username)
+	var u User
+	if err := row.Scan(&u.ID, &u.Username, &u.PasswordHash, &u.Role); err != nil {
+		return User{}, err
+	}
+
+	return u, nil
+}
+
+func (r *SQLiteUserRepository) UpdateUserRole(username, role string) error {
+	const query = `UPDATE users SET role = ? WHERE username = ?;`
+
+	result, err := r.db.Exec(query, role, username)
+	if err != nil {
+		log.Printf("Error updating user role: %v", err)
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// uniqueConstraintError reports which person field a SQLite UNIQUE constraint
+// violation was against, returning nil if err isn't such a violation. SQLite's
+// error message names the violated column (e.g. "persons.email"), which is the
+// only way to tell a duplicate email apart from a duplicate mobile number.
+func uniqueConstraintError(err error) error {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.ExtendedCode != sqlite3.ErrConstraintUnique {
+		return nil
+	}
+
+	switch {
+	case strings.Contains(sqliteErr.Error(), "persons.mobile"):
+		return ErrDuplicateMobile
+	case strings.Contains(sqliteErr.Error(), "persons.email"):
+		return ErrDuplicateEmail
+	default:
+		return ErrDuplicateEmail
+	}
+}
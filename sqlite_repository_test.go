@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func newTestSQLitePersonRepository(t *testing.T) *SQLitePersonRepository {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.db")
+	repo, err := NewSQLitePersonRepository(dsn)
+	if err != nil {
+		t.Fatalf("NewSQLitePersonRepository(%q) error = %v", dsn, err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	if err := repo.CreatePersonsTable(); err != nil {
+		t.Fatalf("CreatePersonsTable() error = %v", err)
+	}
+	return repo
+}
+
+func TestSQLitePersonRepositoryInsertPersonUniqueConstraints(t *testing.T) {
+	repo := newTestSQLitePersonRepository(t)
+
+	if _, err := repo.InsertPerson(Person{Name: "Rayene", Email: "rayene@example.com", Mobile: "0584215400"}); err != nil {
+		t.Fatalf("InsertPerson() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		person Person
+		want   error
+	}{
+		{
+			name:   "duplicate email",
+			person: Person{Name: "Other", Email: "rayene@example.com", Mobile: "0584215401"},
+			want:   ErrDuplicateEmail,
+		},
+		{
+			name:   "duplicate mobile",
+			person: Person{Name: "Other", Email: "other@example.com", Mobile: "0584215400"},
+			want:   ErrDuplicateMobile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repo.InsertPerson(tt.person)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("InsertPerson(%+v) error = %v, want %v", tt.person, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// User struct to represent a row in the 'users' table
+type User struct {
+	ID           *int   `json:"id"`
+	Username     string `json:"username"`
+	PasswordHash string `json:"-"`
+	Role         string `json:"role"`
+}
+
+// credentials is the request body for /register and /login. There is no
+// Role field: callers can never choose their own role, see registerHandler.
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// UserRepository is the storage interface for users, implemented per backend
+// (mirrors PersonRepository) so its queries use the right placeholder syntax.
+type UserRepository interface {
+	CreateUsersTable() error
+	// Register inserts user (username/password hash only; user.Role is
+	// ignored), atomically granting it the "admin" role if and only if it is
+	// the first row ever inserted into the users table. Implementations must
+	// serialize concurrent calls so two simultaneous registrations on a fresh
+	// database can't both become admin.
+	Register(user User) (User, error)
+	GetUserByUsername(username string) (User, error)
+	UpdateUserRole(username, role string) error
+}
+
+// registerHandler creates a new user with a bcrypt-hashed password. Callers
+// can never choose their own role: the first user to register becomes an
+// admin (bootstrapping the system, done atomically by UserRepository.Register
+// so concurrent registrations can't race into multiple admins), and everyone
+// after that is a plain user. Use promoteUserHandler, which requires an
+// authenticated admin, to grant admin rights to anyone else.
+func (a *App) registerHandler(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid JSON data")
+		return
+	}
+
+	if creds.Username == "" || creds.Password == "" {
+		respondWithError(w, http.StatusBadRequest, "username and password are required")
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(creds.Password), bcrypt.DefaultCost)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "failed to hash password")
+		return
+	}
+
+	created, err := a.users.Register(User{Username: creds.Username, PasswordHash: string(hash)})
+	if err != nil {
+		respondWithError(w, http.StatusConflict, "username already taken")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, User{ID: created.ID, Username: created.Username, Role: created.Role})
+}
+
+// promoteUserHandler handles PUT /users/{username}/role. Admin-only: lets an
+// existing admin grant or revoke admin rights for another user.
+func (a *App) promoteUserHandler(w http.ResponseWriter, r *http.Request) {
+	username := mux.Vars(r)["username"]
+
+	var req struct {
+		Role string `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid JSON data")
+		return
+	}
+
+	if req.Role != "admin" && req.Role != "user" {
+		respondWithError(w, http.StatusBadRequest, `role must be "admin" or "user"`)
+		return
+	}
+
+	if err := a.users.UpdateUserRole(username, req.Role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			respondWithError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, "failed to update role")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loginHandler verifies credentials and issues a signed JWT on success.
+func (a *App) loginHandler(w http.ResponseWriter, r *http.Request) {
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid JSON data")
+		return
+	}
+
+	user, err := a.users.GetUserByUsername(creds.Username)
+	if err != nil {
+		respondWithError(w, http.StatusUnauthorized, "invalid username or password")
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(creds.Password)); err != nil {
+		respondWithError(w, http.StatusUnauthorized, "invalid username or password")
+		return
+	}
+
+	token, err := generateToken(user)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "failed to issue token")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"token": token})
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+
+// e164Regex matches a normalized mobile number: an optional leading '+' followed
+// by 8 to 15 digits, mirroring the ParsePhone approach of stripping separators
+// before validating.
+var e164Regex = regexp.MustCompile(`^\+?[0-9]{8,15}$`)
+
+// ParsePhone strips spaces and dashes from a mobile number and checks that the
+// result looks like an E.164 number. It returns the normalized number.
+func ParsePhone(mobile string) (string, bool) {
+	normalized := strings.NewReplacer(" ", "", "-", "").Replace(mobile)
+	if !e164Regex.MatchString(normalized) {
+		return normalized, false
+	}
+	return normalized, true
+}
+
+// ValidationError reports which Person fields failed validation, keyed by field name.
+type ValidationError struct {
+	Fields map[string]string `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	return "validation failed"
+}
+
+// Validate enforces required fields, a well-formed email, and a normalized
+// E.164-ish mobile number. It normalizes p.Mobile in place on success.
+func (p *Person) Validate() error {
+	fields := map[string]string{}
+
+	if strings.TrimSpace(p.Name) == "" {
+		fields["name"] = "name is required"
+	}
+
+	if p.Email != "" && !emailRegex.MatchString(p.Email) {
+		fields["email"] = "email is not a valid address"
+	}
+
+	if strings.TrimSpace(p.Mobile) == "" {
+		fields["mobile"] = "mobile is required"
+	} else if normalized, ok := ParsePhone(p.Mobile); !ok {
+		fields["mobile"] = "mobile must be a valid phone number"
+	} else {
+		p.Mobile = normalized
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// respondWithValidationError writes a 400 response enumerating the fields that
+// failed Person.Validate.
+func respondWithValidationError(w http.ResponseWriter, err *ValidationError) {
+	respondWithJSON(w, http.StatusBadRequest, err)
+}
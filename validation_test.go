@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		name   string
+		mobile string
+		want   string
+		ok     bool
+	}{
+		{"plain digits", "05842154", "05842154", true},
+		{"with spaces", "0 584 215 4", "05842154", true},
+		{"with dashes", "0-584-215-4", "05842154", true},
+		{"e164 with plus", "+213584215400", "+213584215400", true},
+		{"too short", "1234567", "1234567", false},
+		{"too long", "1234567890123456", "1234567890123456", false},
+		{"contains letters", "058-ABCD-54", "058ABCD54", false},
+		{"empty", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParsePhone(tt.mobile)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("ParsePhone(%q) = (%q, %v), want (%q, %v)", tt.mobile, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPersonValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		person     Person
+		wantFields []string // field keys expected in the ValidationError, nil if valid
+	}{
+		{
+			name:   "valid person",
+			person: Person{Name: "Rayene Amina", Email: "rayene@example.com", Mobile: "0584215400"},
+		},
+		{
+			name:   "valid person with no email",
+			person: Person{Name: "Rayene Amina", Mobile: "0584215400"},
+		},
+		{
+			name:       "missing name",
+			person:     Person{Mobile: "0584215400"},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "blank name",
+			person:     Person{Name: "   ", Mobile: "0584215400"},
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "invalid email",
+			person:     Person{Name: "Rayene", Email: "not-an-email", Mobile: "0584215400"},
+			wantFields: []string{"email"},
+		},
+		{
+			name:       "missing mobile",
+			person:     Person{Name: "Rayene"},
+			wantFields: []string{"mobile"},
+		},
+		{
+			name:       "invalid mobile",
+			person:     Person{Name: "Rayene", Mobile: "123"},
+			wantFields: []string{"mobile"},
+		},
+		{
+			name:       "multiple failures",
+			person:     Person{Email: "not-an-email"},
+			wantFields: []string{"name", "email", "mobile"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.person.Validate()
+
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() = %v, want *ValidationError", err)
+			}
+			for _, field := range tt.wantFields {
+				if _, ok := validationErr.Fields[field]; !ok {
+					t.Errorf("Fields = %v, want key %q", validationErr.Fields, field)
+				}
+			}
+			if len(validationErr.Fields) != len(tt.wantFields) {
+				t.Errorf("Fields = %v, want exactly %v", validationErr.Fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestPersonValidateNormalizesMobile(t *testing.T) {
+	p := Person{Name: "Rayene", Mobile: "0-584-215-400"}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+	if p.Mobile != "0584215400" {
+		t.Errorf("Mobile = %q, want normalized %q", p.Mobile, "0584215400")
+	}
+}